@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/RuiSilva365/goAPI/auth"
+	"github.com/RuiSilva365/goAPI/jsend"
+	"github.com/RuiSilva365/goAPI/storage"
+	"github.com/google/uuid"
+)
+
+// sessionTTL is how long a login session stays valid.
+const sessionTTL = 24 * time.Hour
+
+// credentials is the shared register/login request body.
+type credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// userInfo is what account endpoints return for a user; it never includes
+// the password hash.
+type userInfo struct {
+	ID        string    `json:"id"`
+	Username  string    `json:"username"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func toUserInfo(u storage.User) userInfo {
+	return userInfo{ID: u.ID, Username: u.Username, CreatedAt: u.CreatedAt}
+}
+
+func (s *Server) registerAccount(w http.ResponseWriter, r *http.Request) {
+	var creds credentials
+	if err := json.NewDecoder(r.Body).Decode(&creds); err != nil || creds.Username == "" || creds.Password == "" {
+		jsend.Fail(w, http.StatusBadRequest, map[string]string{"credentials": "username and password are required"})
+		return
+	}
+
+	hash, err := auth.HashPassword(creds.Password)
+	if err != nil {
+		jsend.Error(w, http.StatusInternalServerError, "failed to hash password", err)
+		return
+	}
+
+	user, err := s.users.CreateUser(storage.User{
+		ID:           uuid.NewString(),
+		Username:     creds.Username,
+		PasswordHash: hash,
+		CreatedAt:    time.Now(),
+	})
+	if errors.Is(err, storage.ErrUserConflict) {
+		jsend.Fail(w, http.StatusConflict, map[string]string{"username": "already taken"})
+		return
+	}
+	if err != nil {
+		jsend.Error(w, http.StatusInternalServerError, "failed to create user", err)
+		return
+	}
+
+	jsend.Success(w, http.StatusCreated, toUserInfo(user))
+}
+
+func (s *Server) loginAccount(w http.ResponseWriter, r *http.Request) {
+	var creds credentials
+	if err := json.NewDecoder(r.Body).Decode(&creds); err != nil {
+		jsend.Fail(w, http.StatusBadRequest, map[string]string{"body": "invalid JSON"})
+		return
+	}
+
+	user, err := s.users.GetUserByUsername(creds.Username)
+	if err != nil {
+		jsend.Fail(w, http.StatusUnauthorized, map[string]string{"credentials": "invalid username or password"})
+		return
+	}
+
+	ok, err := auth.VerifyPassword(creds.Password, user.PasswordHash)
+	if err != nil || !ok {
+		jsend.Fail(w, http.StatusUnauthorized, map[string]string{"credentials": "invalid username or password"})
+		return
+	}
+
+	token, err := auth.NewSessionToken()
+	if err != nil {
+		jsend.Error(w, http.StatusInternalServerError, "failed to create session", err)
+		return
+	}
+
+	now := time.Now()
+	if err := s.sessions.CreateSession(storage.Session{
+		Token:     token,
+		UserID:    user.ID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(sessionTTL),
+	}); err != nil {
+		jsend.Error(w, http.StatusInternalServerError, "failed to create session", err)
+		return
+	}
+
+	jsend.Success(w, http.StatusOK, map[string]string{"token": token})
+}
+
+func (s *Server) logoutAccount(w http.ResponseWriter, r *http.Request) {
+	token := auth.BearerToken(r)
+
+	err := s.sessions.DeleteSession(token)
+	if err != nil && !errors.Is(err, storage.ErrSessionNotFound) {
+		jsend.Error(w, http.StatusInternalServerError, "failed to end session", err)
+		return
+	}
+	// A missing session (e.g. a double-logout race) still leaves the
+	// caller logged out, so treat it the same as a successful logout.
+	jsend.Success(w, http.StatusOK, nil)
+}
+
+func (s *Server) accountInfo(w http.ResponseWriter, r *http.Request) {
+	user, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		jsend.Fail(w, http.StatusUnauthorized, map[string]string{"authorization": "missing bearer token"})
+		return
+	}
+	jsend.Success(w, http.StatusOK, toUserInfo(*user))
+}
@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/RuiSilva365/goAPI/jsend"
+	"github.com/RuiSilva365/goAPI/storage"
+)
+
+type contextKey int
+
+const userContextKey contextKey = 0
+
+// Middleware resolves the "Authorization: Bearer <token>" header into a
+// *storage.User and stashes it in the request context, rejecting the
+// request with a 401 jsend envelope if the token is missing or invalid.
+func Middleware(sessions storage.SessionStore, users storage.UserStore) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := BearerToken(r)
+			if token == "" {
+				jsend.Fail(w, http.StatusUnauthorized, map[string]string{"authorization": "missing bearer token"})
+				return
+			}
+
+			session, err := sessions.GetSession(token)
+			if err != nil {
+				jsend.Fail(w, http.StatusUnauthorized, map[string]string{"authorization": "invalid or expired session"})
+				return
+			}
+
+			user, err := users.GetUserByID(session.UserID)
+			if err != nil {
+				jsend.Fail(w, http.StatusUnauthorized, map[string]string{"authorization": "invalid or expired session"})
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), userContextKey, &user)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// BearerToken extracts the token from a request's "Authorization: Bearer
+// <token>" header, or "" if the header is missing or doesn't use that
+// scheme.
+func BearerToken(r *http.Request) string {
+	header := r.Header.Get("Authorization")
+	const prefix = "Bearer "
+	if !strings.HasPrefix(header, prefix) {
+		return ""
+	}
+	return strings.TrimPrefix(header, prefix)
+}
+
+// UserFromContext returns the user stashed by Middleware, if any.
+func UserFromContext(ctx context.Context) (*storage.User, bool) {
+	user, ok := ctx.Value(userContextKey).(*storage.User)
+	return user, ok
+}
+
+// OptionalUser resolves the bearer token on r into a user the same way
+// Middleware does, but never rejects the request: it simply reports ok=false
+// when there's no valid session. Handlers that are usable both
+// authenticated and anonymously (e.g. fetching a job's status) use this instead of
+// wrapping themselves in Middleware.
+func OptionalUser(r *http.Request, sessions storage.SessionStore, users storage.UserStore) (*storage.User, bool) {
+	token := BearerToken(r)
+	if token == "" {
+		return nil, false
+	}
+
+	session, err := sessions.GetSession(token)
+	if err != nil {
+		return nil, false
+	}
+
+	user, err := users.GetUserByID(session.UserID)
+	if err != nil {
+		return nil, false
+	}
+	return &user, true
+}
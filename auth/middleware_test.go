@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RuiSilva365/goAPI/storage"
+)
+
+func newTestStores(t *testing.T) (storage.SessionStore, storage.UserStore, storage.User, string) {
+	t.Helper()
+
+	users := storage.NewMemoryUserStore()
+	user, err := users.CreateUser(storage.User{ID: "user-1", Username: "alice"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	sessions := storage.NewMemorySessionStore()
+	token := "test-token"
+	if err := sessions.CreateSession(storage.Session{
+		Token:     token,
+		UserID:    user.ID,
+		CreatedAt: time.Now(),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}); err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+
+	return sessions, users, user, token
+}
+
+func TestMiddlewareRejectsMissingToken(t *testing.T) {
+	sessions, users, _, _ := newTestStores(t)
+	mw := Middleware(sessions, users)
+
+	called := false
+	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/games", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if called {
+		t.Fatal("handler should not run without a bearer token")
+	}
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestMiddlewareRejectsInvalidToken(t *testing.T) {
+	sessions, users, _, _ := newTestStores(t)
+	mw := Middleware(sessions, users)
+
+	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler should not run with an invalid token")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/games", nil)
+	req.Header.Set("Authorization", "Bearer does-not-exist")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestMiddlewareStashesUserForValidToken(t *testing.T) {
+	sessions, users, user, token := newTestStores(t)
+	mw := Middleware(sessions, users)
+
+	var gotUser *storage.User
+	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUser, _ = UserFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/games", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if gotUser == nil || gotUser.ID != user.ID {
+		t.Fatalf("UserFromContext = %+v, want user %s", gotUser, user.ID)
+	}
+}
+
+func TestOptionalUserReportsFalseWithoutToken(t *testing.T) {
+	sessions, users, _, _ := newTestStores(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/games", nil)
+	if _, ok := OptionalUser(req, sessions, users); ok {
+		t.Fatal("OptionalUser should report ok=false without a token")
+	}
+}
+
+func TestOptionalUserResolvesValidToken(t *testing.T) {
+	sessions, users, user, token := newTestStores(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/games", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	got, ok := OptionalUser(req, sessions, users)
+	if !ok {
+		t.Fatal("OptionalUser should resolve a valid token")
+	}
+	if got.ID != user.ID {
+		t.Fatalf("OptionalUser user ID = %s, want %s", got.ID, user.ID)
+	}
+}
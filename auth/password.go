@@ -0,0 +1,96 @@
+// Package auth implements account password hashing, session tokens, and the
+// HTTP middleware that resolves a bearer token into the requesting user.
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// ErrInvalidHash is returned by VerifyPassword when encodedHash isn't in the
+// format HashPassword produces.
+var ErrInvalidHash = errors.New("auth: invalid password hash")
+
+type argonParams struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+	saltLength  uint32
+	keyLength   uint32
+}
+
+var defaultParams = argonParams{
+	memory:      64 * 1024, // 64 MiB
+	iterations:  3,
+	parallelism: 2,
+	saltLength:  16,
+	keyLength:   32,
+}
+
+// HashPassword returns an argon2id hash of password, encoded in the
+// standard $argon2id$v=..$m=..,t=..,p=..$salt$hash format.
+func HashPassword(password string) (string, error) {
+	salt := make([]byte, defaultParams.saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("auth: generate salt: %w", err)
+	}
+
+	hash := argon2.IDKey([]byte(password), salt, defaultParams.iterations, defaultParams.memory, defaultParams.parallelism, defaultParams.keyLength)
+
+	encoded := fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, defaultParams.memory, defaultParams.iterations, defaultParams.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(hash),
+	)
+	return encoded, nil
+}
+
+// VerifyPassword reports whether password matches encodedHash, a string
+// previously returned by HashPassword.
+func VerifyPassword(password, encodedHash string) (bool, error) {
+	params, salt, hash, err := decodeHash(encodedHash)
+	if err != nil {
+		return false, err
+	}
+
+	candidate := argon2.IDKey([]byte(password), salt, params.iterations, params.memory, params.parallelism, params.keyLength)
+	return subtle.ConstantTimeCompare(hash, candidate) == 1, nil
+}
+
+func decodeHash(encodedHash string) (argonParams, []byte, []byte, error) {
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return argonParams{}, nil, nil, ErrInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return argonParams{}, nil, nil, fmt.Errorf("%w: %v", ErrInvalidHash, err)
+	}
+	if version != argon2.Version {
+		return argonParams{}, nil, nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidHash, version)
+	}
+
+	var params argonParams
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.iterations, &params.parallelism); err != nil {
+		return argonParams{}, nil, nil, fmt.Errorf("%w: %v", ErrInvalidHash, err)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return argonParams{}, nil, nil, fmt.Errorf("%w: %v", ErrInvalidHash, err)
+	}
+	hash, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return argonParams{}, nil, nil, fmt.Errorf("%w: %v", ErrInvalidHash, err)
+	}
+	params.keyLength = uint32(len(hash))
+
+	return params, salt, hash, nil
+}
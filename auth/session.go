@@ -0,0 +1,21 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+)
+
+// sessionTokenBytes is the amount of entropy in a session token before
+// base64 encoding.
+const sessionTokenBytes = 32
+
+// NewSessionToken returns a random, base64-encoded bearer token suitable for
+// the Authorization header.
+func NewSessionToken() (string, error) {
+	buf := make([]byte, sessionTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("auth: generate session token: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(buf), nil
+}
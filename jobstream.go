@@ -0,0 +1,224 @@
+package main
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/RuiSilva365/goAPI/auth"
+	"github.com/RuiSilva365/goAPI/jsend"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+)
+
+// pollInterval controls how often the background goroutine checks the
+// Python API for job status updates. Overridable for tests.
+var pollInterval = 500 * time.Millisecond
+
+// maxSubscribersPerJob caps how many concurrent WebSocket clients may
+// watch a single job, so a stuck Python worker can't leak goroutines
+// indefinitely as clients reconnect.
+const maxSubscribersPerJob = 8
+
+var upgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// jobStreams holds the set of subscriber channels for each job ID that is
+// currently being watched by at least one client.
+var jobStreams sync.Map // map[string]*jobSubscribers
+
+// jobStreamsMu serializes "fetch-or-create an entry and add a subscriber"
+// against "check an entry is empty and remove it" for jobStreams. Without
+// it, a subscriber can add() to a *jobSubscribers that a poller has just
+// decided is empty but not yet deleted, leaving that subscriber's channel
+// orphaned from the map and spawning a duplicate poller for the same job.
+var jobStreamsMu sync.Mutex
+
+type jobSubscribers struct {
+	mu   sync.Mutex
+	subs map[chan JobResponse]struct{}
+}
+
+func newJobSubscribers() *jobSubscribers {
+	return &jobSubscribers{subs: make(map[chan JobResponse]struct{})}
+}
+
+// add registers a new subscriber channel and reports whether it is the
+// first one, atomically with the registration itself: checking count()
+// separately afterward would let two concurrent subscribers both observe
+// len(subs) == 2 and conclude neither of them needs to start the poller.
+func (s *jobSubscribers) add() (ch chan JobResponse, first bool, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.subs) >= maxSubscribersPerJob {
+		return nil, false, false
+	}
+	ch = make(chan JobResponse, 1)
+	s.subs[ch] = struct{}{}
+	return ch, len(s.subs) == 1, true
+}
+
+func (s *jobSubscribers) remove(ch chan JobResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.subs, ch)
+}
+
+func (s *jobSubscribers) broadcast(resp JobResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.subs {
+		select {
+		case ch <- resp:
+		default:
+			// Slow subscriber, drop the update rather than block the poller.
+		}
+	}
+}
+
+func (s *jobSubscribers) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.subs)
+}
+
+// deleteJobStreamIfEmpty removes jobID's entry from jobStreams if subs
+// currently has no subscribers, reporting whether it did so. Checking the
+// count and deleting happen under jobStreamsMu so a subscriber concurrently
+// calling add() on the same subs can't land in between them and be
+// orphaned from jobStreams.
+func deleteJobStreamIfEmpty(jobID string, subs *jobSubscribers) bool {
+	jobStreamsMu.Lock()
+	defer jobStreamsMu.Unlock()
+	if subs.count() != 0 {
+		return false
+	}
+	jobStreams.Delete(jobID)
+	return true
+}
+
+// deleteJobStream removes jobID's entry from jobStreams unconditionally,
+// under the same lock as add() so a subscriber joining at the same instant
+// doesn't register against an entry that's about to disappear.
+func deleteJobStream(jobID string) {
+	jobStreamsMu.Lock()
+	defer jobStreamsMu.Unlock()
+	jobStreams.Delete(jobID)
+}
+
+// getJobStatusStream upgrades the connection to a WebSocket and streams
+// JobResponse frames for the given job ID until it reaches a terminal
+// state or the client disconnects.
+func (s *Server) getJobStatusStream(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	jobID := params["id"]
+
+	if owner, tagged := s.jobOwners.Load(jobID); tagged {
+		user, ok := auth.OptionalUser(r, s.sessions, s.users)
+		if !ok || user.ID != owner.(string) {
+			jsend.Fail(w, http.StatusNotFound, map[string]string{"id": "no job with this id"})
+			return
+		}
+	}
+
+	jobStreamsMu.Lock()
+	subsVal, _ := jobStreams.LoadOrStore(jobID, newJobSubscribers())
+	subs := subsVal.(*jobSubscribers)
+	ch, first, ok := subs.add()
+	jobStreamsMu.Unlock()
+	if !ok {
+		jsend.Error(w, http.StatusServiceUnavailable, "too many subscribers for this job", nil)
+		return
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		subs.remove(ch)
+		s.logger.Warn("jobstream: upgrade failed", "job_id", jobID, "error", err)
+		return
+	}
+	defer conn.Close()
+	defer subs.remove(ch)
+
+	if first {
+		go s.pollJobStatus(jobID, subs)
+	}
+
+	done := make(chan struct{})
+	go readPump(conn, done)
+
+	conn.SetReadDeadline(time.Now().Add(60 * time.Second))
+	conn.SetPongHandler(func(string) error {
+		conn.SetReadDeadline(time.Now().Add(60 * time.Second))
+		return nil
+	})
+
+	pingTicker := time.NewTicker(30 * time.Second)
+	defer pingTicker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-pingTicker.C:
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case resp, ok := <-ch:
+			if !ok {
+				return
+			}
+			if err := conn.WriteJSON(resp); err != nil {
+				return
+			}
+			if resp.Status == "completed" || resp.Status == "failed" {
+				return
+			}
+		}
+	}
+}
+
+// readPump drains client messages (pongs, close frames) so the connection
+// doesn't block; it signals done once the client goes away.
+func readPump(conn *websocket.Conn, done chan struct{}) {
+	defer close(done)
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+// pollJobStatus polls the Python API for a job's status at pollInterval,
+// fanning out any change to all current subscribers. It exits once the job
+// reaches a terminal state or runs out of subscribers.
+func (s *Server) pollJobStatus(jobID string, subs *jobSubscribers) {
+	var lastStatus string
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if deleteJobStreamIfEmpty(jobID, subs) {
+			return
+		}
+
+		resp, _, err := s.fetchJobStatus(jobID)
+		if err != nil {
+			s.logger.Warn("jobstream: poll failed", "job_id", jobID, "error", err)
+			continue
+		}
+
+		if resp.Status == lastStatus {
+			continue
+		}
+		lastStatus = resp.Status
+		subs.broadcast(resp)
+
+		if resp.Status == "completed" || resp.Status == "failed" {
+			s.recordJobTerminal(jobID, resp.Status)
+			deleteJobStream(jobID)
+			return
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Package jsend implements the JSend response envelope
+// (https://github.com/omniti-labs/jsend) used by every handler in this API
+// so clients see one consistent JSON shape regardless of which endpoint
+// they call.
+package jsend
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+)
+
+// logger receives the real error passed to Error so it can be diagnosed
+// server-side without exposing internal details (DB error text, driver
+// messages, ...) to API clients. Defaults to slog.Default() until SetLogger
+// is called.
+var logger = slog.Default()
+
+// SetLogger replaces the logger Error reports to. Callers typically call
+// this once at startup with the same *slog.Logger the rest of the server
+// uses.
+func SetLogger(l *slog.Logger) {
+	logger = l
+}
+
+// Status is one of the three JSend response statuses.
+type Status string
+
+const (
+	// StatusSuccess indicates the request was successful; Data holds the
+	// payload.
+	StatusSuccess Status = "success"
+	// StatusFail indicates a client-side problem, e.g. failed validation;
+	// Data describes which fields failed.
+	StatusFail Status = "fail"
+	// StatusError indicates a server-side problem; Message explains what
+	// went wrong.
+	StatusError Status = "error"
+)
+
+// Envelope is the JSON shape returned by every handler.
+type Envelope struct {
+	Status  Status      `json:"status"`
+	Data    interface{} `json:"data,omitempty"`
+	Message string      `json:"message,omitempty"`
+	Code    int         `json:"code,omitempty"`
+}
+
+// Success writes a "success" envelope wrapping payload with the given HTTP
+// status code.
+func Success(w http.ResponseWriter, status int, payload interface{}) {
+	write(w, status, Envelope{Status: StatusSuccess, Data: payload})
+}
+
+// Fail writes a "fail" envelope for client-side problems (validation,
+// missing parameters, not found, ...). data typically describes which
+// fields were invalid.
+func Fail(w http.ResponseWriter, status int, data interface{}) {
+	write(w, status, Envelope{Status: StatusFail, Data: data})
+}
+
+// Error writes an "error" envelope for server-side failures. err may be nil;
+// when present, it is logged server-side rather than sent to the client, so
+// internal details (DB error text, driver messages, ...) never leak into a
+// 500 response body. Only msg, which callers should keep generic and
+// non-sensitive, reaches the client.
+func Error(w http.ResponseWriter, status int, msg string, err error) {
+	if err != nil {
+		logger.Error(msg, "status", status, "error", err)
+	}
+	write(w, status, Envelope{Status: StatusError, Message: msg})
+}
+
+func write(w http.ResponseWriter, status int, env Envelope) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(env)
+}
@@ -1,311 +1,443 @@
 package main
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
-	"io/ioutil"
-	"log"
+	"log/slog"
 	"net/http"
+	"os"
+	"sync"
 	"time"
 
+	"github.com/RuiSilva365/goAPI/auth"
+	"github.com/RuiSilva365/goAPI/jsend"
+	"github.com/RuiSilva365/goAPI/observability"
+	"github.com/RuiSilva365/goAPI/pyclient"
+	"github.com/RuiSilva365/goAPI/ratelimit"
+	"github.com/RuiSilva365/goAPI/storage"
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 )
 
-// Game represents a football match entity
-type Game struct {
-	ID       string    `json:"id"`
-	Name     string    `json:"name"`
-	Team1    string    `json:"team1"`
-	Team2    string    `json:"team2"`
-	GameTime time.Time `json:"game_time"`
-	League   string    `json:"league"`
-	Status   string    `json:"status"`
-	Season   int       `json:"season"`
-}
+// predictionsPerHour is the default per-user prediction quota.
+const predictionsPerHour = 30
+
+// Game represents a football match entity. It is an alias for storage.Game
+// so the wire format handlers work with is identical to what gets persisted.
+type Game = storage.Game
 
 // PredictionRequest represents a request to predict a game outcome
-type PredictionRequest struct {
-	Season   int    `json:"season"`
-	League   string `json:"league"`
-	Team1    string `json:"team1"`
-	Team2    string `json:"team2"`
-	GameDate string `json:"gameDate"`
-}
+type PredictionRequest = pyclient.PredictionRequest
 
 // JobResponse represents the status of a prediction job
-type JobResponse struct {
-	Status  string                 `json:"status"`
-	JobID   string                 `json:"job_id"`
-	Message string                 `json:"message,omitempty"`
-	Result  map[string]interface{} `json:"result,omitempty"`
-	Error   string                 `json:"error,omitempty"`
-}
+type JobResponse = pyclient.JobResponse
 
 // DataResponse represents a response containing DataFrame data
-type DataResponse struct {
-	Status  string                   `json:"status"`
-	Data    []map[string]interface{} `json:"data,omitempty"`
-	Shape   []int                    `json:"shape,omitempty"`
-	Columns []string                 `json:"columns,omitempty"`
-	Message string                   `json:"message,omitempty"`
-}
+type DataResponse = pyclient.DataResponse
 
 // LeaguesResponse represents a response containing available leagues
-type LeaguesResponse struct {
-	Status  string   `json:"status"`
-	Leagues []string `json:"leagues,omitempty"`
-}
+type LeaguesResponse = pyclient.LeaguesResponse
 
 // TeamsResponse represents a response containing teams for a league
-type TeamsResponse struct {
-	Status string   `json:"status"`
-	League string   `json:"league,omitempty"`
-	Teams  []string `json:"teams,omitempty"`
+type TeamsResponse = pyclient.TeamsResponse
+
+// Server holds the dependencies every handler needs, replacing the old
+// package-level globals so state is explicit and testable.
+type Server struct {
+	store    storage.GameStore
+	users    storage.UserStore
+	sessions storage.SessionStore
+	python   *pyclient.Client
+
+	predictLimiter *ratelimit.Limiter
+	metrics        *observability.Metrics
+	logger         *slog.Logger
+
+	// jobOwners tracks which user started which prediction job, so
+	// getJobStatus can scope visibility the same way getGames does. Jobs
+	// themselves live in the Python service; this is just the ownership tag.
+	jobOwners sync.Map // map[jobID]userID
+	// jobStarted tracks when each job was submitted, so that once it reaches
+	// a terminal state prediction_job_duration_seconds can be observed
+	// exactly once.
+	jobStarted sync.Map // map[jobID]time.Time
 }
 
-var games []Game
-var pythonAPIURL = "http://localhost:5000/api" // URL of the Python Flask API
-
 func main() {
+	storageBackend := flag.String("storage", "memory", "game storage backend: memory, sqlite, postgres")
+	enablePprof := flag.Bool("pprof", false, "mount net/http/pprof handlers under /debug/pprof/")
+	flag.Parse()
+
+	logger := observability.NewLogger()
+	jsend.SetLogger(logger)
+
+	dsn := os.Getenv("STORAGE_DSN")
+	stores, err := storage.Open(*storageBackend, dsn)
+	if err != nil {
+		logger.Error("failed to open storage backend", "backend", *storageBackend, "error", err)
+		os.Exit(1)
+	}
+
+	pythonAPIURL := os.Getenv("PYTHON_API_URL")
+	if pythonAPIURL == "" {
+		pythonAPIURL = "http://localhost:5000/api"
+	}
+	python := pyclient.NewClient(pyclient.Config{BaseURL: pythonAPIURL})
+
+	s := &Server{
+		store:          stores.Games,
+		users:          stores.Users,
+		sessions:       stores.Sessions,
+		python:         python,
+		predictLimiter: ratelimit.NewLimiter(predictionsPerHour, time.Hour),
+		metrics:        observability.NewMetrics(),
+		logger:         logger,
+	}
+	authRequired := auth.Middleware(s.sessions, s.users)
+
 	r := mux.NewRouter()
 
+	// Account endpoints
+	r.HandleFunc("/api/account/register", s.registerAccount).Methods("POST")
+	r.HandleFunc("/api/account/login", s.loginAccount).Methods("POST")
+	r.Handle("/api/account/logout", authRequired(http.HandlerFunc(s.logoutAccount))).Methods("GET")
+	r.Handle("/api/account/info", authRequired(http.HandlerFunc(s.accountInfo))).Methods("GET")
+
 	// Game management endpoints
-	r.HandleFunc("/games", getGames).Methods("GET")
-	r.HandleFunc("/games/{id}", getGame).Methods("GET")
-	r.HandleFunc("/games", createGame).Methods("POST")
+	r.Handle("/games", authRequired(http.HandlerFunc(s.getGames))).Methods("GET")
+	r.Handle("/games/{id}", authRequired(http.HandlerFunc(s.getGame))).Methods("GET")
+	r.Handle("/games", authRequired(http.HandlerFunc(s.createGame))).Methods("POST")
+	r.Handle("/games/{id}", authRequired(http.HandlerFunc(s.updateGame))).Methods("PUT")
+	r.Handle("/games/{id}", authRequired(http.HandlerFunc(s.deleteGame))).Methods("DELETE")
 
 	// ML Prediction endpoints
-	r.HandleFunc("/predict", startPrediction).Methods("POST")
-	r.HandleFunc("/jobs/{id}", getJobStatus).Methods("GET")
-	r.HandleFunc("/data/team", getTeamData).Methods("GET")
-	r.HandleFunc("/data/next-game", getNextGameData).Methods("GET")
-	r.HandleFunc("/leagues", getLeagues).Methods("GET")
-	r.HandleFunc("/teams", getTeams).Methods("GET")
-
-	// Add middleware to handle CORS
+	r.Handle("/predict", authRequired(http.HandlerFunc(s.startPrediction))).Methods("POST")
+	r.HandleFunc("/jobs/{id}", s.getJobStatus).Methods("GET")
+	r.HandleFunc("/jobs/{id}/stream", s.getJobStatusStream).Methods("GET")
+	r.HandleFunc("/data/team", s.getTeamData).Methods("GET")
+	r.HandleFunc("/data/next-game", s.getNextGameData).Methods("GET")
+	r.HandleFunc("/leagues", s.getLeagues).Methods("GET")
+	r.HandleFunc("/teams", s.getTeams).Methods("GET")
+
+	// Observability endpoints
+	observability.MountMetrics(r)
+	if *enablePprof {
+		observability.MountPprof(r)
+	}
+
+	// Add middleware to handle CORS and structured request logging
 	r.Use(corsMiddleware)
-	log.Println("Server started on :8080")
-	log.Fatal(http.ListenAndServe(":8080", r))
+	r.Use(observability.RequestLogger(logger, s.metrics))
+
+	logger.Info("server started", "addr", ":8080", "storage", *storageBackend, "pprof", *enablePprof)
+	if err := http.ListenAndServe(":8080", r); err != nil {
+		logger.Error("server stopped", "error", err)
+		os.Exit(1)
+	}
 }
 
 // Game management handlers
-func getGames(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(games)
+func (s *Server) getGames(w http.ResponseWriter, r *http.Request) {
+	user, _ := auth.UserFromContext(r.Context())
+
+	q := r.URL.Query()
+	filter := storage.Filter{
+		League:  q.Get("league"),
+		Status:  q.Get("status"),
+		OwnerID: user.ID,
+	}
+	games, err := s.store.List(filter)
+	if err != nil {
+		jsend.Error(w, http.StatusInternalServerError, "failed to list games", err)
+		return
+	}
+	jsend.Success(w, http.StatusOK, games)
 }
 
-func getGame(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
+func (s *Server) getGame(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
+	user, _ := auth.UserFromContext(r.Context())
 
-	for _, game := range games {
-		if game.ID == params["id"] {
-			json.NewEncoder(w).Encode(game)
-			return
-		}
+	game, err := s.store.Get(params["id"])
+	if errors.Is(err, storage.ErrNotFound) {
+		jsend.Fail(w, http.StatusNotFound, map[string]string{"id": "no game with this id"})
+		return
 	}
-	w.WriteHeader(http.StatusNotFound)
-	json.NewEncoder(w).Encode(map[string]string{"message": "Game not found"})
+	if err != nil {
+		jsend.Error(w, http.StatusInternalServerError, "failed to fetch game", err)
+		return
+	}
+	if game.OwnerID != user.ID {
+		jsend.Fail(w, http.StatusNotFound, map[string]string{"id": "no game with this id"})
+		return
+	}
+	jsend.Success(w, http.StatusOK, game)
 }
 
-func createGame(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
+func (s *Server) createGame(w http.ResponseWriter, r *http.Request) {
 	var game Game
-	err := json.NewDecoder(r.Body).Decode(&game)
-	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(map[string]string{"message": "Invalid request"})
+	if err := json.NewDecoder(r.Body).Decode(&game); err != nil {
+		jsend.Fail(w, http.StatusBadRequest, map[string]string{"body": "invalid JSON"})
 		return
 	}
 
-	games = append(games, game)
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(game)
-}
+	if game.ID == "" {
+		game.ID = uuid.NewString()
+	}
 
-// ML Prediction handlers
-func startPrediction(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
+	if user, ok := auth.UserFromContext(r.Context()); ok {
+		game.OwnerID = user.ID
+	}
 
-	var predReq PredictionRequest
-	err := json.NewDecoder(r.Body).Decode(&predReq)
-	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(map[string]string{"message": "Invalid request format"})
+	created, err := s.store.Create(game)
+	if errors.Is(err, storage.ErrConflict) {
+		jsend.Fail(w, http.StatusConflict, map[string]string{"id": "a game with this id already exists"})
 		return
 	}
-
-	// Forward request to Python API
-	jsonData, err := json.Marshal(predReq)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{"message": "Failed to marshal request"})
+		jsend.Error(w, http.StatusInternalServerError, "failed to create game", err)
 		return
 	}
+	jsend.Success(w, http.StatusCreated, created)
+}
 
-	resp, err := http.Post(pythonAPIURL+"/predict", "application/json", bytes.NewBuffer(jsonData))
-	if err != nil {
-		w.WriteHeader(http.StatusServiceUnavailable)
-		json.NewEncoder(w).Encode(map[string]string{"message": "Python API unavailable", "error": err.Error()})
+func (s *Server) updateGame(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	user, _ := auth.UserFromContext(r.Context())
+
+	var game Game
+	if err := json.NewDecoder(r.Body).Decode(&game); err != nil {
+		jsend.Fail(w, http.StatusBadRequest, map[string]string{"body": "invalid JSON"})
 		return
 	}
-	defer resp.Body.Close()
 
-	body, err := ioutil.ReadAll(resp.Body)
+	existing, err := s.store.Get(params["id"])
+	if errors.Is(err, storage.ErrNotFound) {
+		jsend.Fail(w, http.StatusNotFound, map[string]string{"id": "no game with this id"})
+		return
+	}
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{"message": "Failed to read response"})
+		jsend.Error(w, http.StatusInternalServerError, "failed to fetch game", err)
 		return
 	}
+	if existing.OwnerID != user.ID {
+		jsend.Fail(w, http.StatusNotFound, map[string]string{"id": "no game with this id"})
+		return
+	}
+
+	// The owner of a game is set at creation time and can't be reassigned by
+	// a client-supplied body.
+	game.OwnerID = existing.OwnerID
 
-	var jobResponse JobResponse
-	err = json.Unmarshal(body, &jobResponse)
+	updated, err := s.store.Update(params["id"], game)
+	if errors.Is(err, storage.ErrNotFound) {
+		jsend.Fail(w, http.StatusNotFound, map[string]string{"id": "no game with this id"})
+		return
+	}
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{"message": "Failed to parse response"})
+		jsend.Error(w, http.StatusInternalServerError, "failed to update game", err)
 		return
 	}
-
-	// Return the job ID and status
-	w.WriteHeader(resp.StatusCode)
-	json.NewEncoder(w).Encode(jobResponse)
+	jsend.Success(w, http.StatusOK, updated)
 }
 
-func getJobStatus(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
+func (s *Server) deleteGame(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
-	jobID := params["id"]
+	user, _ := auth.UserFromContext(r.Context())
 
-	// Forward request to Python API
-	resp, err := http.Get(fmt.Sprintf("%s/jobs/%s", pythonAPIURL, jobID))
-	if err != nil {
-		w.WriteHeader(http.StatusServiceUnavailable)
-		json.NewEncoder(w).Encode(map[string]string{"message": "Python API unavailable", "error": err.Error()})
+	existing, err := s.store.Get(params["id"])
+	if errors.Is(err, storage.ErrNotFound) {
+		jsend.Fail(w, http.StatusNotFound, map[string]string{"id": "no game with this id"})
 		return
 	}
-	defer resp.Body.Close()
-
-	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{"message": "Failed to read response"})
+		jsend.Error(w, http.StatusInternalServerError, "failed to fetch game", err)
+		return
+	}
+	if existing.OwnerID != user.ID {
+		jsend.Fail(w, http.StatusNotFound, map[string]string{"id": "no game with this id"})
 		return
 	}
 
-	// Forward the response from Python API
-	w.WriteHeader(resp.StatusCode)
-	w.Write(body)
+	if err := s.store.Delete(params["id"]); err != nil {
+		jsend.Error(w, http.StatusInternalServerError, "failed to delete game", err)
+		return
+	}
+	jsend.Success(w, http.StatusOK, nil)
 }
 
-func getTeamData(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
+// ML Prediction handlers
+func (s *Server) startPrediction(w http.ResponseWriter, r *http.Request) {
+	user, _ := auth.UserFromContext(r.Context())
 
-	team := r.URL.Query().Get("team")
-	if team == "" {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(map[string]string{"message": "Team parameter is required"})
+	if allowed, retryAfter := s.predictLimiter.Allow(user.ID); !allowed {
+		w.Header().Set("Retry-After", fmt.Sprintf("%.0f", retryAfter.Seconds()))
+		jsend.Fail(w, http.StatusTooManyRequests, map[string]string{"rate_limit": "prediction quota exceeded"})
 		return
 	}
 
-	// Forward request to Python API
-	resp, err := http.Get(fmt.Sprintf("%s/data/team?team=%s", pythonAPIURL, team))
+	var predReq PredictionRequest
+	err := json.NewDecoder(r.Body).Decode(&predReq)
 	if err != nil {
-		w.WriteHeader(http.StatusServiceUnavailable)
-		json.NewEncoder(w).Encode(map[string]string{"message": "Python API unavailable", "error": err.Error()})
+		jsend.Fail(w, http.StatusBadRequest, map[string]string{"body": "invalid request format"})
 		return
 	}
-	defer resp.Body.Close()
 
-	body, err := ioutil.ReadAll(resp.Body)
+	jobResponse, status, err := s.python.Predict(r.Context(), predReq)
+	s.recordPythonCall("predict", err)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{"message": "Failed to read response"})
+		writePythonError(w, err)
+		return
+	}
+	if status >= 400 {
+		jsend.Fail(w, status, jobResponse)
 		return
 	}
+	s.jobOwners.Store(jobResponse.JobID, user.ID)
+	s.jobStarted.Store(jobResponse.JobID, time.Now())
+	s.metrics.PredictionJobsInflight.Inc()
 
-	// Forward the response from Python API
-	w.WriteHeader(resp.StatusCode)
-	w.Write(body)
+	jsend.Success(w, status, jobResponse)
 }
 
-func getNextGameData(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
+func (s *Server) getJobStatus(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	jobID := params["id"]
 
-	// Forward request to Python API
-	resp, err := http.Get(pythonAPIURL + "/data/next-game")
+	if owner, tagged := s.jobOwners.Load(jobID); tagged {
+		user, ok := auth.OptionalUser(r, s.sessions, s.users)
+		if !ok || user.ID != owner.(string) {
+			jsend.Fail(w, http.StatusNotFound, map[string]string{"id": "no job with this id"})
+			return
+		}
+	}
+
+	jobResponse, status, err := s.fetchJobStatus(jobID)
 	if err != nil {
-		w.WriteHeader(http.StatusServiceUnavailable)
-		json.NewEncoder(w).Encode(map[string]string{"message": "Python API unavailable", "error": err.Error()})
+		writePythonError(w, err)
 		return
 	}
-	defer resp.Body.Close()
+	if status >= 400 {
+		jsend.Fail(w, status, jobResponse)
+		return
+	}
+	s.recordJobTerminal(jobID, jobResponse.Status)
 
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{"message": "Failed to read response"})
+	jsend.Success(w, status, jobResponse)
+}
+
+// fetchJobStatus asks the Python API for the current status of a job. It is
+// shared by the polling GET handler and the WebSocket streaming handler.
+func (s *Server) fetchJobStatus(jobID string) (JobResponse, int, error) {
+	resp, status, err := s.python.JobStatus(context.Background(), jobID)
+	s.recordPythonCall("job_status", err)
+	return resp, status, err
+}
+
+// recordJobTerminal observes prediction_job_duration_seconds and decrements
+// prediction_jobs_inflight exactly once, the first time a job is seen in a
+// terminal state.
+func (s *Server) recordJobTerminal(jobID, status string) {
+	if status != "completed" && status != "failed" {
 		return
 	}
+	startedAt, ok := s.jobStarted.LoadAndDelete(jobID)
+	if !ok {
+		return
+	}
+	s.metrics.PredictionJobsInflight.Dec()
+	s.metrics.PredictionJobDuration.Observe(time.Since(startedAt.(time.Time)).Seconds())
+}
 
-	// Forward the response from Python API
-	w.WriteHeader(resp.StatusCode)
-	w.Write(body)
+// recordPythonCall records a round trip to the Python API against
+// python_api_requests_total.
+func (s *Server) recordPythonCall(endpoint string, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	s.metrics.ObservePythonAPIRequest(endpoint, outcome)
 }
 
-func getLeagues(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
+func (s *Server) getTeamData(w http.ResponseWriter, r *http.Request) {
+	team := r.URL.Query().Get("team")
+	if team == "" {
+		jsend.Fail(w, http.StatusBadRequest, map[string]string{"team": "team parameter is required"})
+		return
+	}
 
-	// Forward request to Python API
-	resp, err := http.Get(pythonAPIURL + "/leagues")
+	data, status, err := s.python.TeamData(r.Context(), team)
+	s.recordPythonCall("team_data", err)
 	if err != nil {
-		w.WriteHeader(http.StatusServiceUnavailable)
-		json.NewEncoder(w).Encode(map[string]string{"message": "Python API unavailable", "error": err.Error()})
+		writePythonError(w, err)
+		return
+	}
+	if status >= 400 {
+		jsend.Fail(w, status, data)
 		return
 	}
-	defer resp.Body.Close()
 
-	body, err := ioutil.ReadAll(resp.Body)
+	jsend.Success(w, status, data)
+}
+
+func (s *Server) getNextGameData(w http.ResponseWriter, r *http.Request) {
+	data, status, err := s.python.NextGame(r.Context())
+	s.recordPythonCall("next_game", err)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{"message": "Failed to read response"})
+		writePythonError(w, err)
+		return
+	}
+	if status >= 400 {
+		jsend.Fail(w, status, data)
 		return
 	}
 
-	// Forward the response from Python API
-	w.WriteHeader(resp.StatusCode)
-	w.Write(body)
+	jsend.Success(w, status, data)
 }
 
-func getTeams(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
+func (s *Server) getLeagues(w http.ResponseWriter, r *http.Request) {
+	leagues, status, err := s.python.Leagues(r.Context())
+	s.recordPythonCall("leagues", err)
+	if err != nil {
+		writePythonError(w, err)
+		return
+	}
+	if status >= 400 {
+		jsend.Fail(w, status, leagues)
+		return
+	}
 
+	jsend.Success(w, status, leagues)
+}
+
+func (s *Server) getTeams(w http.ResponseWriter, r *http.Request) {
 	league := r.URL.Query().Get("league")
 	if league == "" {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(map[string]string{"message": "League parameter is required"})
+		jsend.Fail(w, http.StatusBadRequest, map[string]string{"league": "league parameter is required"})
 		return
 	}
 
-	// Forward request to Python API
-	resp, err := http.Get(fmt.Sprintf("%s/teams?league=%s", pythonAPIURL, league))
+	teams, status, err := s.python.Teams(r.Context(), league)
+	s.recordPythonCall("teams", err)
 	if err != nil {
-		w.WriteHeader(http.StatusServiceUnavailable)
-		json.NewEncoder(w).Encode(map[string]string{"message": "Python API unavailable", "error": err.Error()})
+		writePythonError(w, err)
 		return
 	}
-	defer resp.Body.Close()
-
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{"message": "Failed to read response"})
+	if status >= 400 {
+		jsend.Fail(w, status, teams)
 		return
 	}
 
-	// Forward the response from Python API
-	w.WriteHeader(resp.StatusCode)
-	w.Write(body)
+	jsend.Success(w, status, teams)
+}
+
+// writePythonError maps any pyclient failure (network error, 5xx, or an open
+// circuit breaker) to a 503 jsend envelope.
+func writePythonError(w http.ResponseWriter, err error) {
+	jsend.Error(w, http.StatusServiceUnavailable, "Python API unavailable", err)
 }
 
 func corsMiddleware(next http.Handler) http.Handler {
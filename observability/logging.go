@@ -0,0 +1,112 @@
+package observability
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// NewLogger returns a slog.Logger that writes structured JSON to stdout.
+func NewLogger() *slog.Logger {
+	return slog.New(slog.NewJSONHandler(os.Stdout, nil))
+}
+
+// requestIDHeader is echoed back to the client so it can correlate its
+// request with the server-side log line.
+const requestIDHeader = "X-Request-ID"
+
+// responseRecorder captures the status code and byte count a handler wrote,
+// since http.ResponseWriter doesn't expose them after the fact.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+	return n, err
+}
+
+// Hijack passes through to the wrapped writer so WebSocket upgrades (e.g.
+// /jobs/{id}/stream) still work behind this middleware.
+func (r *responseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hj, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("observability: response writer does not support hijacking")
+	}
+	return hj.Hijack()
+}
+
+// RequestLogger returns middleware that logs one JSON line per request via
+// logger and records m.HTTPRequestsTotal / m.HTTPRequestDuration for it. A
+// request ID is generated per request, stashed on the response via
+// X-Request-ID, and included in the log line.
+func RequestLogger(logger *slog.Logger, m *Metrics) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := newRequestID()
+			w.Header().Set(requestIDHeader, requestID)
+
+			rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+			start := time.Now()
+
+			next.ServeHTTP(rec, r)
+
+			duration := time.Since(start)
+			status := strconv.Itoa(rec.status)
+
+			logger.Info("http_request",
+				"request_id", requestID,
+				"method", r.Method,
+				"path", r.URL.Path,
+				"status", rec.status,
+				"duration_ms", duration.Milliseconds(),
+				"bytes", rec.bytes,
+				"remote_addr", r.RemoteAddr,
+			)
+
+			if m != nil {
+				m.ObserveHTTPRequest(routeTemplate(r), r.Method, status, duration)
+			}
+		})
+	}
+}
+
+// routeTemplate returns the mux route template matched for r (e.g.
+// "/games/{id}") rather than the literal path, so path-parameterized routes
+// don't each mint their own permanent time series in the route label.
+func routeTemplate(r *http.Request) string {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return r.URL.Path
+	}
+	tpl, err := route.GetPathTemplate()
+	if err != nil {
+		return r.URL.Path
+	}
+	return tpl
+}
+
+func newRequestID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(buf)
+}
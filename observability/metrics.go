@@ -0,0 +1,84 @@
+// Package observability wires up Prometheus metrics, pprof profiling, and
+// structured request logging for the API server.
+package observability
+
+import (
+	"net/http/pprof"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics bundles every collector the server reports.
+type Metrics struct {
+	HTTPRequestsTotal      *prometheus.CounterVec
+	HTTPRequestDuration    *prometheus.HistogramVec
+	PythonAPIRequestsTotal *prometheus.CounterVec
+	PredictionJobsInflight prometheus.Gauge
+	PredictionJobDuration  prometheus.Histogram
+}
+
+// NewMetrics registers every collector against the default registerer and
+// returns them for handlers to record against.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		HTTPRequestsTotal: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Total HTTP requests handled, by route, method and status.",
+		}, []string{"route", "method", "status"}),
+
+		HTTPRequestDuration: promauto.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "HTTP request latency in seconds.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"route", "method", "status"}),
+
+		PythonAPIRequestsTotal: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name: "python_api_requests_total",
+			Help: "Total requests made to the Python API, by endpoint and outcome.",
+		}, []string{"endpoint", "outcome"}),
+
+		PredictionJobsInflight: promauto.NewGauge(prometheus.GaugeOpts{
+			Name: "prediction_jobs_inflight",
+			Help: "Number of prediction jobs currently awaiting a terminal status.",
+		}),
+
+		PredictionJobDuration: promauto.NewHistogram(prometheus.HistogramOpts{
+			Name:    "prediction_job_duration_seconds",
+			Help:    "Time from starting a prediction job to it reaching a terminal status.",
+			Buckets: prometheus.DefBuckets,
+		}),
+	}
+}
+
+// ObserveHTTPRequest records one completed HTTP request.
+func (m *Metrics) ObserveHTTPRequest(route, method, status string, duration time.Duration) {
+	m.HTTPRequestsTotal.WithLabelValues(route, method, status).Inc()
+	m.HTTPRequestDuration.WithLabelValues(route, method, status).Observe(duration.Seconds())
+}
+
+// ObservePythonAPIRequest records one round trip to the Python API.
+func (m *Metrics) ObservePythonAPIRequest(endpoint, outcome string) {
+	m.PythonAPIRequestsTotal.WithLabelValues(endpoint, outcome).Inc()
+}
+
+// MountMetrics registers the /metrics endpoint against r.
+func MountMetrics(r *mux.Router) {
+	r.Handle("/metrics", promhttp.Handler())
+}
+
+// MountPprof registers the net/http/pprof handlers under /debug/pprof/. It
+// is only wired up when the --pprof flag is set, since it exposes internal
+// runtime state.
+func MountPprof(r *mux.Router) {
+	r.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	r.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	r.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	r.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	// Registered last as a prefix so named profiles (heap, goroutine, ...)
+	// reach pprof.Index without shadowing the handlers above.
+	r.PathPrefix("/debug/pprof/").HandlerFunc(pprof.Index)
+}
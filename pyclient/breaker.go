@@ -0,0 +1,83 @@
+package pyclient
+
+import (
+	"sync"
+	"time"
+)
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// circuitBreaker is a simple consecutive-failure breaker: it opens after
+// failureThreshold consecutive failures, stays open for cooldown, then lets
+// a single half-open probe through before deciding whether to close or
+// re-open.
+type circuitBreaker struct {
+	failureThreshold int
+	cooldown         time.Duration
+
+	mu          sync.Mutex
+	state       breakerState
+	failures    int
+	openedAt    time.Time
+	probeActive bool
+}
+
+func newCircuitBreaker(failureThreshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{failureThreshold: failureThreshold, cooldown: cooldown}
+}
+
+// allow reports whether a request may proceed, transitioning open->half-open
+// once the cooldown has elapsed.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerClosed:
+		return true
+	case breakerOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = breakerHalfOpen
+		b.probeActive = true
+		return true
+	case breakerHalfOpen:
+		// Only the probe request that flipped us into half-open may proceed;
+		// everything else fails fast until it resolves.
+		return false
+	}
+	return false
+}
+
+func (b *circuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.state = breakerClosed
+	b.probeActive = false
+}
+
+func (b *circuitBreaker) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == breakerHalfOpen {
+		b.state = breakerOpen
+		b.openedAt = time.Now()
+		b.probeActive = false
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.failureThreshold {
+		b.state = breakerOpen
+		b.openedAt = time.Now()
+	}
+}
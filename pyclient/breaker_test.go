@@ -0,0 +1,89 @@
+package pyclient
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerOpensAfterThreshold(t *testing.T) {
+	b := newCircuitBreaker(3, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		if !b.allow() {
+			t.Fatalf("allow() = false before threshold reached (failure %d)", i)
+		}
+		b.recordFailure()
+	}
+	if !b.allow() {
+		t.Fatal("allow() = false one failure short of the threshold")
+	}
+	b.recordFailure()
+
+	if b.allow() {
+		t.Fatal("allow() = true, want breaker open after threshold consecutive failures")
+	}
+}
+
+func TestCircuitBreakerHalfOpenProbeRecovers(t *testing.T) {
+	b := newCircuitBreaker(1, time.Millisecond)
+
+	if !b.allow() {
+		t.Fatal("allow() = false on first request")
+	}
+	b.recordFailure() // opens the breaker
+
+	time.Sleep(5 * time.Millisecond)
+
+	if !b.allow() {
+		t.Fatal("allow() = false for the half-open probe after cooldown")
+	}
+	if b.allow() {
+		t.Fatal("allow() = true for a second request while the probe is still in flight")
+	}
+
+	b.recordSuccess()
+	if !b.allow() {
+		t.Fatal("allow() = false after the probe succeeded, want breaker closed")
+	}
+}
+
+func TestCircuitBreakerHalfOpenProbeFailureReopens(t *testing.T) {
+	b := newCircuitBreaker(1, time.Millisecond)
+
+	b.allow()
+	b.recordFailure() // opens the breaker
+
+	time.Sleep(5 * time.Millisecond)
+	b.allow() // admits the half-open probe
+	b.recordFailure()
+
+	if b.allow() {
+		t.Fatal("allow() = true immediately after the probe failed, want breaker re-opened")
+	}
+}
+
+// TestCircuitBreakerAbandonedProbeMustResolve guards against the breaker
+// wedging in half-open forever: any exit path for the probe request
+// (success, failure, or an abandoned request such as a caller's context
+// being cancelled) must call recordSuccess or recordFailure, or every
+// future call is permanently refused.
+func TestCircuitBreakerAbandonedProbeMustResolve(t *testing.T) {
+	b := newCircuitBreaker(1, time.Millisecond)
+
+	b.allow()
+	b.recordFailure() // opens the breaker
+
+	time.Sleep(5 * time.Millisecond)
+	if !b.allow() {
+		t.Fatal("allow() = false for the half-open probe after cooldown")
+	}
+
+	// Simulate the probe's context being cancelled: the caller must still
+	// resolve the breaker state rather than abandoning it.
+	b.recordFailure()
+
+	time.Sleep(5 * time.Millisecond)
+	if !b.allow() {
+		t.Fatal("breaker stuck in half-open: a resolved probe must allow a new probe after cooldown")
+	}
+}
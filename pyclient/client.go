@@ -0,0 +1,239 @@
+// Package pyclient is a resilient typed client for the Python prediction
+// service. It replaces the ad-hoc http.Get/http.Post calls that used to be
+// duplicated across handlers with a shared client that applies timeouts,
+// retries with backoff, and a circuit breaker so a down Python service fails
+// fast instead of hanging every handler that talks to it.
+package pyclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// ErrCircuitOpen is returned when the breaker is open and a request is
+// rejected without being sent.
+var ErrCircuitOpen = errors.New("pyclient: circuit breaker open")
+
+// Config configures a Client.
+type Config struct {
+	// BaseURL is the root of the Python API, e.g. "http://localhost:5000/api".
+	BaseURL string
+	// Timeout bounds a single HTTP attempt; the backoff waits between
+	// retries are not counted against it.
+	Timeout time.Duration
+	// MaxRetries is how many additional attempts a retryable GET gets after
+	// the first failure.
+	MaxRetries int
+	// BackoffBase is the base delay for exponential backoff between retries;
+	// the delay before retry n (counting from 1) is BackoffBase * 2^(n-1),
+	// plus up to BackoffBase of jitter.
+	BackoffBase time.Duration
+	// FailureThreshold is how many consecutive failures open the breaker.
+	FailureThreshold int
+	// Cooldown is how long the breaker stays open before allowing a
+	// half-open probe.
+	Cooldown time.Duration
+}
+
+// withDefaults fills in zero-value fields with sensible defaults.
+func (c Config) withDefaults() Config {
+	if c.Timeout == 0 {
+		c.Timeout = 10 * time.Second
+	}
+	if c.MaxRetries == 0 {
+		c.MaxRetries = 3
+	}
+	if c.BackoffBase == 0 {
+		c.BackoffBase = 100 * time.Millisecond
+	}
+	if c.FailureThreshold == 0 {
+		c.FailureThreshold = 5
+	}
+	if c.Cooldown == 0 {
+		c.Cooldown = 30 * time.Second
+	}
+	return c
+}
+
+// Client is a resilient HTTP client for the Python prediction API.
+type Client struct {
+	cfg        Config
+	httpClient *http.Client
+	breaker    *circuitBreaker
+}
+
+// NewClient builds a Client from cfg, applying defaults for any zero-value
+// fields.
+func NewClient(cfg Config) *Client {
+	cfg = cfg.withDefaults()
+	return &Client{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+		breaker:    newCircuitBreaker(cfg.FailureThreshold, cfg.Cooldown),
+	}
+}
+
+// Predict's second return value is the Python service's HTTP status code
+// whenever a response was actually received, so callers can distinguish a
+// validation rejection (4xx) from success instead of seeing everything as
+// 200. It is 0 if the request never got a response (network error, breaker
+// open).
+func (c *Client) Predict(ctx context.Context, req PredictionRequest) (JobResponse, int, error) {
+	var out JobResponse
+	body, err := json.Marshal(req)
+	if err != nil {
+		return out, 0, fmt.Errorf("pyclient: marshal predict request: %w", err)
+	}
+	status, err := c.post(ctx, "/predict", body, &out)
+	return out, status, err
+}
+
+func (c *Client) JobStatus(ctx context.Context, id string) (JobResponse, int, error) {
+	var out JobResponse
+	status, err := c.getRetrying(ctx, fmt.Sprintf("/jobs/%s", id), &out)
+	return out, status, err
+}
+
+func (c *Client) TeamData(ctx context.Context, team string) (DataResponse, int, error) {
+	var out DataResponse
+	status, err := c.getRetrying(ctx, fmt.Sprintf("/data/team?team=%s", team), &out)
+	return out, status, err
+}
+
+func (c *Client) Leagues(ctx context.Context) (LeaguesResponse, int, error) {
+	var out LeaguesResponse
+	status, err := c.getRetrying(ctx, "/leagues", &out)
+	return out, status, err
+}
+
+func (c *Client) Teams(ctx context.Context, league string) (TeamsResponse, int, error) {
+	var out TeamsResponse
+	status, err := c.getRetrying(ctx, fmt.Sprintf("/teams?league=%s", league), &out)
+	return out, status, err
+}
+
+func (c *Client) NextGame(ctx context.Context) (DataResponse, int, error) {
+	var out DataResponse
+	status, err := c.getRetrying(ctx, "/data/next-game", &out)
+	return out, status, err
+}
+
+// getRetrying performs a GET, retrying on network errors and 5xx responses
+// with exponential backoff and jitter, gated by the circuit breaker. It
+// returns the upstream status code whenever a response was received, even
+// when err is non-nil, so callers can tell a 5xx that exhausted retries from
+// a network error that never reached the Python service.
+func (c *Client) getRetrying(ctx context.Context, path string, out interface{}) (int, error) {
+	if !c.breaker.allow() {
+		return 0, ErrCircuitOpen
+	}
+
+	var lastErr error
+	var lastStatus int
+	for attempt := 0; attempt <= c.cfg.MaxRetries; attempt++ {
+		if attempt > 0 {
+			delay := c.cfg.BackoffBase * (1 << uint(attempt-1))
+			delay += time.Duration(rand.Int63n(int64(c.cfg.BackoffBase)))
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				// The breaker may be waiting on this very attempt to resolve
+				// its half-open probe; abandoning it without recording a
+				// result would wedge the breaker in half-open forever.
+				c.breaker.recordFailure()
+				return 0, ctx.Err()
+			}
+		}
+
+		status, err := c.doGet(ctx, path, out)
+		lastStatus = status
+		if err == nil {
+			c.breaker.recordSuccess()
+			return status, nil
+		}
+		lastErr = err
+		if status != 0 && status < 500 {
+			// Client errors aren't retryable; network errors (status 0) are.
+			c.breaker.recordFailure()
+			return status, err
+		}
+	}
+	c.breaker.recordFailure()
+	return lastStatus, lastErr
+}
+
+func (c *Client) doGet(ctx context.Context, path string, out interface{}) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.cfg.BaseURL+path, nil)
+	if err != nil {
+		return 0, fmt.Errorf("pyclient: build request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("pyclient: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, fmt.Errorf("pyclient: read response: %w", err)
+	}
+
+	if resp.StatusCode >= 500 {
+		return resp.StatusCode, fmt.Errorf("pyclient: server error: %d", resp.StatusCode)
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return resp.StatusCode, fmt.Errorf("pyclient: decode response: %w", err)
+	}
+	return resp.StatusCode, nil
+}
+
+// post sends a non-idempotent POST; it is not retried, but still goes
+// through the breaker so a known-down Python service fails fast. Like
+// getRetrying, it returns the upstream status code whenever a response was
+// received.
+func (c *Client) post(ctx context.Context, path string, body []byte, out interface{}) (int, error) {
+	if !c.breaker.allow() {
+		return 0, ErrCircuitOpen
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.BaseURL+path, bytes.NewReader(body))
+	if err != nil {
+		c.breaker.recordFailure()
+		return 0, fmt.Errorf("pyclient: build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.breaker.recordFailure()
+		return 0, fmt.Errorf("pyclient: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		c.breaker.recordFailure()
+		return resp.StatusCode, fmt.Errorf("pyclient: read response: %w", err)
+	}
+
+	if resp.StatusCode >= 500 {
+		c.breaker.recordFailure()
+		return resp.StatusCode, fmt.Errorf("pyclient: server error: %d", resp.StatusCode)
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		c.breaker.recordFailure()
+		return resp.StatusCode, fmt.Errorf("pyclient: decode response: %w", err)
+	}
+	c.breaker.recordSuccess()
+	return resp.StatusCode, nil
+}
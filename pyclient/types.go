@@ -0,0 +1,41 @@
+package pyclient
+
+// PredictionRequest represents a request to predict a game outcome
+type PredictionRequest struct {
+	Season   int    `json:"season"`
+	League   string `json:"league"`
+	Team1    string `json:"team1"`
+	Team2    string `json:"team2"`
+	GameDate string `json:"gameDate"`
+}
+
+// JobResponse represents the status of a prediction job
+type JobResponse struct {
+	Status  string                 `json:"status"`
+	JobID   string                 `json:"job_id"`
+	Message string                 `json:"message,omitempty"`
+	Result  map[string]interface{} `json:"result,omitempty"`
+	Error   string                 `json:"error,omitempty"`
+}
+
+// DataResponse represents a response containing DataFrame data
+type DataResponse struct {
+	Status  string                   `json:"status"`
+	Data    []map[string]interface{} `json:"data,omitempty"`
+	Shape   []int                    `json:"shape,omitempty"`
+	Columns []string                 `json:"columns,omitempty"`
+	Message string                   `json:"message,omitempty"`
+}
+
+// LeaguesResponse represents a response containing available leagues
+type LeaguesResponse struct {
+	Status  string   `json:"status"`
+	Leagues []string `json:"leagues,omitempty"`
+}
+
+// TeamsResponse represents a response containing teams for a league
+type TeamsResponse struct {
+	Status string   `json:"status"`
+	League string   `json:"league,omitempty"`
+	Teams  []string `json:"teams,omitempty"`
+}
@@ -0,0 +1,72 @@
+// Package ratelimit implements a per-key token bucket, used to cap how many
+// predictions each user may start per hour.
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+type tokenBucket struct {
+	mu         sync.Mutex
+	tokens     float64
+	capacity   float64
+	refillRate float64 // tokens per second
+	lastRefill time.Time
+}
+
+func newTokenBucket(capacity int, period time.Duration) *tokenBucket {
+	return &tokenBucket{
+		tokens:     float64(capacity),
+		capacity:   float64(capacity),
+		refillRate: float64(capacity) / period.Seconds(),
+		lastRefill: time.Now(),
+	}
+}
+
+// allow reports whether a token is available. When it isn't, it also
+// returns how long the caller should wait before retrying.
+func (b *tokenBucket) allow() (bool, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens = math.Min(b.capacity, b.tokens+now.Sub(b.lastRefill).Seconds()*b.refillRate)
+	b.lastRefill = now
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / b.refillRate * float64(time.Second))
+		return false, wait
+	}
+	b.tokens--
+	return true, 0
+}
+
+// Limiter hands out a token bucket per key, e.g. per user ID.
+type Limiter struct {
+	mu       sync.Mutex
+	buckets  map[string]*tokenBucket
+	capacity int
+	period   time.Duration
+}
+
+// NewLimiter returns a Limiter where each key may make capacity calls per
+// period.
+func NewLimiter(capacity int, period time.Duration) *Limiter {
+	return &Limiter{buckets: make(map[string]*tokenBucket), capacity: capacity, period: period}
+}
+
+// Allow reports whether key may proceed, and if not, how long it should
+// wait before retrying.
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = newTokenBucket(l.capacity, l.period)
+		l.buckets[key] = bucket
+	}
+	l.mu.Unlock()
+
+	return bucket.allow()
+}
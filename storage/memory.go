@@ -0,0 +1,87 @@
+package storage
+
+import "sync"
+
+// MemoryStore is the default GameStore: a map guarded by a RWMutex. Data does
+// not survive a restart.
+type MemoryStore struct {
+	mu    sync.RWMutex
+	games map[string]Game
+}
+
+// NewMemoryStore returns an empty MemoryStore ready for use.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{games: make(map[string]Game)}
+}
+
+func (s *MemoryStore) Create(game Game) (Game, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.games[game.ID]; exists {
+		return Game{}, ErrConflict
+	}
+	s.games[game.ID] = game
+	return game, nil
+}
+
+func (s *MemoryStore) Get(id string) (Game, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	game, ok := s.games[id]
+	if !ok {
+		return Game{}, ErrNotFound
+	}
+	return game, nil
+}
+
+func (s *MemoryStore) List(filter Filter) ([]Game, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	games := make([]Game, 0, len(s.games))
+	for _, game := range s.games {
+		if filter.League != "" && game.League != filter.League {
+			continue
+		}
+		if filter.Season != 0 && game.Season != filter.Season {
+			continue
+		}
+		if filter.Status != "" && game.Status != filter.Status {
+			continue
+		}
+		if filter.OwnerID != "" && game.OwnerID != filter.OwnerID {
+			continue
+		}
+		games = append(games, game)
+	}
+	return games, nil
+}
+
+func (s *MemoryStore) Update(id string, game Game) (Game, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.games[id]; !ok {
+		return Game{}, ErrNotFound
+	}
+	game.ID = id
+	s.games[id] = game
+	return game, nil
+}
+
+func (s *MemoryStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.games[id]; !ok {
+		return ErrNotFound
+	}
+	delete(s.games, id)
+	return nil
+}
+
+func (s *MemoryStore) ListByLeagueSeason(league string, season int) ([]Game, error) {
+	return s.List(Filter{League: league, Season: season})
+}
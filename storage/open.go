@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "github.com/lib/pq"
+	_ "modernc.org/sqlite"
+)
+
+// Stores bundles every persistence interface the server depends on, all
+// backed by the same storage backend.
+type Stores struct {
+	Games    GameStore
+	Users    UserStore
+	Sessions SessionStore
+}
+
+// Open selects a storage backend by name and wires up Games, Users and
+// Sessions stores against it. "memory" (the default) ignores dsn and is not
+// durable across restarts. "sqlite" and "postgres" share a single *sql.DB
+// across all three stores, opened against dsn using the matching
+// database/sql driver (modernc.org/sqlite or lib/pq, blank-imported above).
+// Callers are responsible for running the migrations under
+// storage/migrations before first use of a SQL backend.
+func Open(backend, dsn string) (Stores, error) {
+	switch backend {
+	case "", "memory":
+		return Stores{
+			Games:    NewMemoryStore(),
+			Users:    NewMemoryUserStore(),
+			Sessions: NewMemorySessionStore(),
+		}, nil
+	case "sqlite":
+		return openSQL("sqlite", dsn)
+	case "postgres":
+		return openSQL("postgres", dsn)
+	default:
+		return Stores{}, fmt.Errorf("storage: unknown backend %q", backend)
+	}
+}
+
+func openSQL(driverName, dsn string) (Stores, error) {
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return Stores{}, fmt.Errorf("storage: open %s: %w", driverName, err)
+	}
+	if err := db.Ping(); err != nil {
+		return Stores{}, fmt.Errorf("storage: ping %s: %w", driverName, err)
+	}
+	return Stores{
+		Games:    &SQLStore{db: db},
+		Users:    NewSQLUserStore(db),
+		Sessions: NewSQLSessionStore(db),
+	}, nil
+}
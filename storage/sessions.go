@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrSessionNotFound is returned when no session matches the given token, or
+// when it has expired.
+var ErrSessionNotFound = errors.New("storage: session not found")
+
+// Session is a logged-in session, keyed by a random bearer token.
+type Session struct {
+	Token     string    `json:"-"`
+	UserID    string    `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// SessionStore persists Sessions. Implementations must be safe for
+// concurrent use.
+type SessionStore interface {
+	CreateSession(session Session) error
+	GetSession(token string) (Session, error)
+	DeleteSession(token string) error
+}
+
+// MemorySessionStore is the default SessionStore: a map guarded by a
+// RWMutex.
+type MemorySessionStore struct {
+	mu       sync.RWMutex
+	sessions map[string]Session
+}
+
+// NewMemorySessionStore returns an empty MemorySessionStore ready for use.
+func NewMemorySessionStore() *MemorySessionStore {
+	return &MemorySessionStore{sessions: make(map[string]Session)}
+}
+
+func (s *MemorySessionStore) CreateSession(session Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[session.Token] = session
+	return nil
+}
+
+func (s *MemorySessionStore) GetSession(token string) (Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	session, ok := s.sessions[token]
+	if !ok || time.Now().After(session.ExpiresAt) {
+		return Session{}, ErrSessionNotFound
+	}
+	return session, nil
+}
+
+func (s *MemorySessionStore) DeleteSession(token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.sessions[token]; !ok {
+		return ErrSessionNotFound
+	}
+	delete(s.sessions, token)
+	return nil
+}
+
+// SQLSessionStore is a database/sql-backed SessionStore.
+type SQLSessionStore struct {
+	db *sql.DB
+}
+
+// NewSQLSessionStore wraps an already-open *sql.DB. Callers are responsible
+// for running the migrations under storage/migrations before first use.
+func NewSQLSessionStore(db *sql.DB) *SQLSessionStore {
+	return &SQLSessionStore{db: db}
+}
+
+func (s *SQLSessionStore) CreateSession(session Session) error {
+	_, err := s.db.Exec(
+		`INSERT INTO sessions (token, user_id, created_at, expires_at) VALUES ($1, $2, $3, $4)`,
+		session.Token, session.UserID, session.CreatedAt, session.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("storage: create session: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLSessionStore) GetSession(token string) (Session, error) {
+	row := s.db.QueryRow(
+		`SELECT token, user_id, created_at, expires_at FROM sessions WHERE token = $1`, token,
+	)
+	var session Session
+	err := row.Scan(&session.Token, &session.UserID, &session.CreatedAt, &session.ExpiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Session{}, ErrSessionNotFound
+	}
+	if err != nil {
+		return Session{}, fmt.Errorf("storage: scan session: %w", err)
+	}
+	if time.Now().After(session.ExpiresAt) {
+		return Session{}, ErrSessionNotFound
+	}
+	return session, nil
+}
+
+func (s *SQLSessionStore) DeleteSession(token string) error {
+	res, err := s.db.Exec(`DELETE FROM sessions WHERE token = $1`, token)
+	if err != nil {
+		return fmt.Errorf("storage: delete session: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrSessionNotFound
+	}
+	return nil
+}
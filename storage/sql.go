@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SQLStore is a database/sql-backed GameStore. It works against any driver
+// registered for the *sql.DB passed to it (modernc.org/sqlite for local/dev
+// use, lib/pq in production), since it only relies on standard SQL
+// supported by both.
+type SQLStore struct {
+	db *sql.DB
+}
+
+func (s *SQLStore) Create(game Game) (Game, error) {
+	_, err := s.db.Exec(
+		`INSERT INTO games (id, name, team1, team2, game_time, league, status, season, owner_id)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		game.ID, game.Name, game.Team1, game.Team2, game.GameTime, game.League, game.Status, game.Season, game.OwnerID,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return Game{}, ErrConflict
+		}
+		return Game{}, fmt.Errorf("storage: create game: %w", err)
+	}
+	return game, nil
+}
+
+func (s *SQLStore) Get(id string) (Game, error) {
+	row := s.db.QueryRow(
+		`SELECT id, name, team1, team2, game_time, league, status, season, owner_id FROM games WHERE id = $1`, id,
+	)
+	var game Game
+	err := row.Scan(&game.ID, &game.Name, &game.Team1, &game.Team2, &game.GameTime, &game.League, &game.Status, &game.Season, &game.OwnerID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Game{}, ErrNotFound
+	}
+	if err != nil {
+		return Game{}, fmt.Errorf("storage: get game: %w", err)
+	}
+	return game, nil
+}
+
+func (s *SQLStore) List(filter Filter) ([]Game, error) {
+	query := `SELECT id, name, team1, team2, game_time, league, status, season, owner_id FROM games WHERE 1=1`
+	var args []interface{}
+
+	if filter.League != "" {
+		args = append(args, filter.League)
+		query += fmt.Sprintf(" AND league = $%d", len(args))
+	}
+	if filter.Season != 0 {
+		args = append(args, filter.Season)
+		query += fmt.Sprintf(" AND season = $%d", len(args))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		query += fmt.Sprintf(" AND status = $%d", len(args))
+	}
+	if filter.OwnerID != "" {
+		args = append(args, filter.OwnerID)
+		query += fmt.Sprintf(" AND owner_id = $%d", len(args))
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("storage: list games: %w", err)
+	}
+	defer rows.Close()
+
+	var games []Game
+	for rows.Next() {
+		var game Game
+		if err := rows.Scan(&game.ID, &game.Name, &game.Team1, &game.Team2, &game.GameTime, &game.League, &game.Status, &game.Season, &game.OwnerID); err != nil {
+			return nil, fmt.Errorf("storage: scan game: %w", err)
+		}
+		games = append(games, game)
+	}
+	return games, rows.Err()
+}
+
+func (s *SQLStore) Update(id string, game Game) (Game, error) {
+	res, err := s.db.Exec(
+		`UPDATE games SET name = $1, team1 = $2, team2 = $3, game_time = $4, league = $5, status = $6, season = $7, owner_id = $8
+		 WHERE id = $9`,
+		game.Name, game.Team1, game.Team2, game.GameTime, game.League, game.Status, game.Season, game.OwnerID, id,
+	)
+	if err != nil {
+		return Game{}, fmt.Errorf("storage: update game: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return Game{}, ErrNotFound
+	}
+	game.ID = id
+	return game, nil
+}
+
+func (s *SQLStore) Delete(id string) error {
+	res, err := s.db.Exec(`DELETE FROM games WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("storage: delete game: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *SQLStore) ListByLeagueSeason(league string, season int) ([]Game, error) {
+	return s.List(Filter{League: league, Season: season})
+}
+
+// isUniqueViolation is a best-effort check since the exact error type
+// differs between modernc.org/sqlite and lib/pq.
+func isUniqueViolation(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "UNIQUE constraint") || strings.Contains(msg, "duplicate key value")
+}
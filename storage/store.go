@@ -0,0 +1,50 @@
+// Package storage defines the persistence layer for games and provides
+// in-memory and SQL-backed implementations behind a common interface so the
+// HTTP handlers don't need to care which one is active.
+package storage
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned by Get, Update and Delete when no game matches
+// the given ID.
+var ErrNotFound = errors.New("storage: game not found")
+
+// ErrConflict is returned by Create when a game with the same ID already
+// exists.
+var ErrConflict = errors.New("storage: game already exists")
+
+// Game is the API-level Game entity. It is defined here, and aliased by the
+// main package, so storage stays free of a dependency on the HTTP layer.
+type Game struct {
+	ID       string    `json:"id"`
+	Name     string    `json:"name"`
+	Team1    string    `json:"team1"`
+	Team2    string    `json:"team2"`
+	GameTime time.Time `json:"game_time"`
+	League   string    `json:"league"`
+	Status   string    `json:"status"`
+	Season   int       `json:"season"`
+	OwnerID  string    `json:"owner_id,omitempty"`
+}
+
+// Filter narrows a List call. Zero-value fields are ignored.
+type Filter struct {
+	League  string
+	Season  int
+	Status  string
+	OwnerID string
+}
+
+// GameStore persists Game records. Implementations must be safe for
+// concurrent use.
+type GameStore interface {
+	Create(game Game) (Game, error)
+	Get(id string) (Game, error)
+	List(filter Filter) ([]Game, error)
+	Update(id string, game Game) (Game, error)
+	Delete(id string) error
+	ListByLeagueSeason(league string, season int) ([]Game, error)
+}
@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrUserNotFound is returned when no user matches the given username or ID.
+var ErrUserNotFound = errors.New("storage: user not found")
+
+// ErrUserConflict is returned by CreateUser when the username is already
+// taken.
+var ErrUserConflict = errors.New("storage: username already taken")
+
+// User is an account. PasswordHash holds an argon2id hash, never the
+// plaintext password.
+type User struct {
+	ID           string    `json:"id"`
+	Username     string    `json:"username"`
+	PasswordHash string    `json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// UserStore persists User accounts. Implementations must be safe for
+// concurrent use.
+type UserStore interface {
+	CreateUser(user User) (User, error)
+	GetUserByUsername(username string) (User, error)
+	GetUserByID(id string) (User, error)
+}
+
+// MemoryUserStore is the default UserStore: a map guarded by a RWMutex.
+type MemoryUserStore struct {
+	mu    sync.RWMutex
+	users map[string]User // keyed by ID
+}
+
+// NewMemoryUserStore returns an empty MemoryUserStore ready for use.
+func NewMemoryUserStore() *MemoryUserStore {
+	return &MemoryUserStore{users: make(map[string]User)}
+}
+
+func (s *MemoryUserStore) CreateUser(user User) (User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.users {
+		if existing.Username == user.Username {
+			return User{}, ErrUserConflict
+		}
+	}
+	s.users[user.ID] = user
+	return user, nil
+}
+
+func (s *MemoryUserStore) GetUserByUsername(username string) (User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, user := range s.users {
+		if user.Username == username {
+			return user, nil
+		}
+	}
+	return User{}, ErrUserNotFound
+}
+
+func (s *MemoryUserStore) GetUserByID(id string) (User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	user, ok := s.users[id]
+	if !ok {
+		return User{}, ErrUserNotFound
+	}
+	return user, nil
+}
+
+// SQLUserStore is a database/sql-backed UserStore.
+type SQLUserStore struct {
+	db *sql.DB
+}
+
+// NewSQLUserStore wraps an already-open *sql.DB. Callers are responsible for
+// running the migrations under storage/migrations before first use.
+func NewSQLUserStore(db *sql.DB) *SQLUserStore {
+	return &SQLUserStore{db: db}
+}
+
+func (s *SQLUserStore) CreateUser(user User) (User, error) {
+	_, err := s.db.Exec(
+		`INSERT INTO users (id, username, password_hash, created_at) VALUES ($1, $2, $3, $4)`,
+		user.ID, user.Username, user.PasswordHash, user.CreatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return User{}, ErrUserConflict
+		}
+		return User{}, fmt.Errorf("storage: create user: %w", err)
+	}
+	return user, nil
+}
+
+func (s *SQLUserStore) GetUserByUsername(username string) (User, error) {
+	row := s.db.QueryRow(`SELECT id, username, password_hash, created_at FROM users WHERE username = $1`, username)
+	return scanUser(row)
+}
+
+func (s *SQLUserStore) GetUserByID(id string) (User, error) {
+	row := s.db.QueryRow(`SELECT id, username, password_hash, created_at FROM users WHERE id = $1`, id)
+	return scanUser(row)
+}
+
+func scanUser(row *sql.Row) (User, error) {
+	var user User
+	err := row.Scan(&user.ID, &user.Username, &user.PasswordHash, &user.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return User{}, ErrUserNotFound
+	}
+	if err != nil {
+		return User{}, fmt.Errorf("storage: scan user: %w", err)
+	}
+	return user, nil
+}